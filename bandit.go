@@ -0,0 +1,69 @@
+package bandit
+
+import "fmt"
+
+// Bandit is the common interface implemented by every multi-armed bandit
+// algorithm in this package. It lets callers treat UCB, and any algorithm
+// added alongside it, interchangeably.
+type Bandit interface {
+	// Init (re)initialises the algorithm's internal state for nArms arms.
+	Init(nArms int) error
+	// SelectArm picks the next arm to play. r is a caller-supplied random
+	// draw in [0, 1); algorithms that don't need randomness may ignore it.
+	SelectArm(r float64) int
+	// Update records the reward observed for the chosen arm.
+	Update(arm int, reward float64) error
+	// GetCounts returns a copy of the per-arm play counts.
+	GetCounts() []int
+	// GetRewards returns a copy of the per-arm reward estimates.
+	GetRewards() []float64
+}
+
+// Factory constructs a new, uninitialised Bandit for a registered algorithm
+// name.
+type Factory func() Bandit
+
+var registry = map[string]Factory{
+	"ucb": func() Bandit { return &UCB{} },
+}
+
+// Register adds an algorithm to the registry under name, overwriting any
+// algorithm already registered under that name. Algorithms defined outside
+// this package can call Register from an init() function to make themselves
+// selectable through New.
+func Register(name string, factory Factory) {
+	registry[name] = factory
+}
+
+// New looks up the algorithm registered under name, constructs it and
+// initialises it for nArms arms.
+func New(name string, nArms int) (Bandit, error) {
+	factory, ok := registry[name]
+	if !ok {
+		return nil, fmt.Errorf("bandit: unknown algorithm %q", name)
+	}
+
+	b := factory()
+	if err := b.Init(nArms); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+// Gang wraps any Bandit and adds convenience helpers that don't care which
+// underlying algorithm is in use.
+type Gang struct {
+	Bandit
+}
+
+// NewGang wraps an existing Bandit so it can be used through the Gang
+// helpers.
+func NewGang(b Bandit) *Gang {
+	return &Gang{Bandit: b}
+}
+
+// AllocateSolution returns a snapshot of the current counts and rewards for
+// every arm in a single call.
+func (g *Gang) AllocateSolution() ([]int, []float64) {
+	return g.GetCounts(), g.GetRewards()
+}
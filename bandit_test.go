@@ -0,0 +1,52 @@
+package bandit
+
+import "testing"
+
+func TestNewUnknownAlgorithm(t *testing.T) {
+	if _, err := New("does-not-exist", 2); err == nil {
+		t.Fatal("expected an error for an unregistered algorithm name")
+	}
+}
+
+func TestNewConstructsAndInitialises(t *testing.T) {
+	b, err := New("ucb", 3)
+	if err != nil {
+		t.Fatalf("New returned an error: %v", err)
+	}
+	if got := len(b.GetCounts()); got != 3 {
+		t.Fatalf("expected 3 arms, got %d", got)
+	}
+}
+
+func TestRegisterOverridesEntry(t *testing.T) {
+	called := false
+	Register("ucb", func() Bandit {
+		called = true
+		return &UCB{}
+	})
+	defer Register("ucb", func() Bandit { return &UCB{} })
+
+	if _, err := New("ucb", 2); err != nil {
+		t.Fatalf("New returned an error: %v", err)
+	}
+	if !called {
+		t.Fatal("expected the overriding factory to be used")
+	}
+}
+
+func TestGangAllocateSolution(t *testing.T) {
+	u, err := NewUCB([]int{1, 2}, []float64{0.5, 0.25})
+	if err != nil {
+		t.Fatalf("NewUCB returned an error: %v", err)
+	}
+
+	g := NewGang(u)
+	counts, rewards := g.AllocateSolution()
+
+	if len(counts) != 2 || counts[0] != 1 || counts[1] != 2 {
+		t.Fatalf("unexpected counts: %v", counts)
+	}
+	if len(rewards) != 2 || rewards[0] != 0.5 || rewards[1] != 0.25 {
+		t.Fatalf("unexpected rewards: %v", rewards)
+	}
+}
@@ -0,0 +1,61 @@
+package bandit
+
+import "testing"
+
+func TestLinUCBPrefersHigherReward(t *testing.T) {
+	l, err := NewLinUCB(2, 2, 0.1)
+	if err != nil {
+		t.Fatalf("NewLinUCB returned an error: %v", err)
+	}
+
+	ctx := []float64{1, 0}
+	for i := 0; i < 20; i++ {
+		if err := l.Update(0, ctx, 1); err != nil {
+			t.Fatalf("Update returned an error: %v", err)
+		}
+		if err := l.Update(1, ctx, 0); err != nil {
+			t.Fatalf("Update returned an error: %v", err)
+		}
+	}
+
+	if got := l.SelectArm(ctx); got != 0 {
+		t.Fatalf("SelectArm = %d, want 0 (the consistently rewarding arm)", got)
+	}
+}
+
+func TestLinUCBSMMatchesLinUCB(t *testing.T) {
+	naive, err := NewLinUCB(2, 2, 0.1)
+	if err != nil {
+		t.Fatalf("NewLinUCB returned an error: %v", err)
+	}
+	sm, err := NewLinUCBSM(2, 2, 0.1)
+	if err != nil {
+		t.Fatalf("NewLinUCBSM returned an error: %v", err)
+	}
+
+	contexts := [][]float64{{1, 0}, {0, 1}, {1, 1}, {0.5, 0.5}}
+	rewards := []float64{1, 0, 0.5, 0.2}
+
+	for i, ctx := range contexts {
+		if err := naive.Update(i%2, ctx, rewards[i]); err != nil {
+			t.Fatalf("naive Update returned an error: %v", err)
+		}
+		if err := sm.Update(i%2, ctx, rewards[i]); err != nil {
+			t.Fatalf("sm Update returned an error: %v", err)
+		}
+	}
+
+	probe := []float64{0.3, 0.7}
+	if got, want := sm.SelectArm(probe), naive.SelectArm(probe); got != want {
+		t.Fatalf("LinUCBSM.SelectArm = %d, want %d (same as LinUCB)", got, want)
+	}
+}
+
+func TestLinUCBInitRejectsBadDimensions(t *testing.T) {
+	if _, err := NewLinUCB(0, 2, 0.1); err == nil {
+		t.Fatal("expected an error for 0 arms")
+	}
+	if _, err := NewLinUCB(2, 0, 0.1); err == nil {
+		t.Fatal("expected an error for 0 features")
+	}
+}
@@ -0,0 +1,30 @@
+package bandit
+
+import "errors"
+
+var (
+	// ErrInvalidArms is returned when an algorithm is initialised with fewer
+	// than one arm.
+	ErrInvalidArms = errors.New("bandit: invalid number of arms")
+	// ErrArmsIndexOutOfRange is returned when an update refers to an arm
+	// index outside the initialised range.
+	ErrArmsIndexOutOfRange = errors.New("bandit: arm index out of range")
+	// ErrInvalidReward is returned when a reward value is outside the range
+	// an algorithm supports.
+	ErrInvalidReward = errors.New("bandit: invalid reward")
+	// ErrInvalidLength is returned when counts and rewards slices passed to
+	// a constructor don't have the same length.
+	ErrInvalidLength = errors.New("bandit: counts and rewards must have the same length")
+	// ErrInvalidFeatureDimension is returned when a context vector's length
+	// doesn't match the feature dimension an algorithm was initialised with.
+	ErrInvalidFeatureDimension = errors.New("bandit: context does not match feature dimension")
+	// ErrSingularMatrix is returned when a per-arm design matrix cannot be
+	// inverted.
+	ErrSingularMatrix = errors.New("bandit: singular matrix")
+	// ErrNotFound is returned by a Storage backend when no state has been
+	// saved under the requested name.
+	ErrNotFound = errors.New("bandit: not found")
+	// ErrInvalidWindow is returned when a sliding-window algorithm is
+	// configured with a window size smaller than one.
+	ErrInvalidWindow = errors.New("bandit: invalid window size")
+)
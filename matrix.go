@@ -0,0 +1,128 @@
+package bandit
+
+// matrix is a minimal dense, row-major square matrix used by the contextual
+// bandit algorithms to track per-arm design matrices. It only implements the
+// handful of operations LinUCB needs.
+type matrix struct {
+	n    int
+	rows [][]float64
+}
+
+// identity returns the n×n identity matrix.
+func identity(n int) *matrix {
+	rows := make([][]float64, n)
+	for i := range rows {
+		rows[i] = make([]float64, n)
+		rows[i][i] = 1
+	}
+	return &matrix{n: n, rows: rows}
+}
+
+// addOuter adds v*v^T to m in place.
+func (m *matrix) addOuter(v []float64) {
+	for i := 0; i < m.n; i++ {
+		for j := 0; j < m.n; j++ {
+			m.rows[i][j] += v[i] * v[j]
+		}
+	}
+}
+
+// mulVec returns m*v.
+func (m *matrix) mulVec(v []float64) []float64 {
+	out := make([]float64, m.n)
+	for i := 0; i < m.n; i++ {
+		out[i] = dot(m.rows[i], v)
+	}
+	return out
+}
+
+// quadForm returns v^T * m * v.
+func (m *matrix) quadForm(v []float64) float64 {
+	return dot(v, m.mulVec(v))
+}
+
+// invert returns the inverse of m computed via Gauss-Jordan elimination with
+// partial pivoting. It leaves m untouched.
+func (m *matrix) invert() (*matrix, error) {
+	n := m.n
+
+	work := make([][]float64, n)
+	inv := identity(n)
+	for i := 0; i < n; i++ {
+		work[i] = append([]float64(nil), m.rows[i]...)
+	}
+
+	for col := 0; col < n; col++ {
+		pivot := col
+		for row := col + 1; row < n; row++ {
+			if abs(work[row][col]) > abs(work[pivot][col]) {
+				pivot = row
+			}
+		}
+		if work[pivot][col] == 0 {
+			return nil, ErrSingularMatrix
+		}
+		work[col], work[pivot] = work[pivot], work[col]
+		inv.rows[col], inv.rows[pivot] = inv.rows[pivot], inv.rows[col]
+
+		scale := 1 / work[col][col]
+		for j := 0; j < n; j++ {
+			work[col][j] *= scale
+			inv.rows[col][j] *= scale
+		}
+
+		for row := 0; row < n; row++ {
+			if row == col {
+				continue
+			}
+			factor := work[row][col]
+			if factor == 0 {
+				continue
+			}
+			for j := 0; j < n; j++ {
+				work[row][j] -= factor * work[col][j]
+				inv.rows[row][j] -= factor * inv.rows[col][j]
+			}
+		}
+	}
+
+	return inv, nil
+}
+
+// shermanMorrisonUpdate rewrites m (assumed to already hold A^-1) in place to
+// hold the inverse of (A + v*v^T), using the Sherman-Morrison identity:
+//
+//	(A + v*v^T)^-1 = A^-1 - (A^-1*v*v^T*A^-1) / (1 + v^T*A^-1*v)
+func (m *matrix) shermanMorrisonUpdate(v []float64) {
+	av := m.mulVec(v)
+	denom := 1 + dot(v, av)
+	for i := 0; i < m.n; i++ {
+		for j := 0; j < m.n; j++ {
+			m.rows[i][j] -= (av[i] * av[j]) / denom
+		}
+	}
+}
+
+// dot returns the dot product of a and b.
+func dot(a, b []float64) float64 {
+	total := 0.0
+	for i := range a {
+		total += a[i] * b[i]
+	}
+	return total
+}
+
+// axpy computes dst += alpha*x in place.
+func axpy(dst []float64, alpha float64, x []float64) {
+	for i := range dst {
+		dst[i] += alpha * x[i]
+	}
+}
+
+// abs returns the absolute value of f.
+func abs(f float64) float64 {
+	if f < 0 {
+		return -f
+	}
+	return f
+}
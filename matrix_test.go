@@ -0,0 +1,77 @@
+package bandit
+
+import "testing"
+
+// approxEqual reports whether a and b differ by no more than tol.
+func approxEqual(a, b, tol float64) bool {
+	d := a - b
+	if d < 0 {
+		d = -d
+	}
+	return d <= tol
+}
+
+func TestMatrixInvertIdentity(t *testing.T) {
+	inv, err := identity(3).invert()
+	if err != nil {
+		t.Fatalf("invert returned an error: %v", err)
+	}
+	for i := 0; i < 3; i++ {
+		for j := 0; j < 3; j++ {
+			want := 0.0
+			if i == j {
+				want = 1
+			}
+			if !approxEqual(inv.rows[i][j], want, 1e-9) {
+				t.Fatalf("inv[%d][%d] = %v, want %v", i, j, inv.rows[i][j], want)
+			}
+		}
+	}
+}
+
+func TestMatrixInvertRoundTrip(t *testing.T) {
+	m := identity(2)
+	m.addOuter([]float64{1, 2})
+	m.addOuter([]float64{3, -1})
+
+	inv, err := m.invert()
+	if err != nil {
+		t.Fatalf("invert returned an error: %v", err)
+	}
+
+	// m * inv should be the identity matrix.
+	for i := 0; i < 2; i++ {
+		row := m.mulVec([]float64{inv.rows[0][i], inv.rows[1][i]})
+		for j, got := range row {
+			want := 0.0
+			if i == j {
+				want = 1
+			}
+			if !approxEqual(got, want, 1e-6) {
+				t.Fatalf("(m*inv)[%d][%d] = %v, want %v", j, i, got, want)
+			}
+		}
+	}
+}
+
+func TestShermanMorrisonMatchesFullInverse(t *testing.T) {
+	v := []float64{0.7, -1.3, 2.0}
+
+	full := identity(3)
+	full.addOuter(v)
+	wantInv, err := full.invert()
+	if err != nil {
+		t.Fatalf("invert returned an error: %v", err)
+	}
+
+	sm := identity(3)
+	sm.shermanMorrisonUpdate(v)
+
+	for i := 0; i < 3; i++ {
+		for j := 0; j < 3; j++ {
+			if !approxEqual(sm.rows[i][j], wantInv.rows[i][j], 1e-9) {
+				t.Fatalf("sm[%d][%d] = %v, want %v", i, j, sm.rows[i][j], wantInv.rows[i][j])
+			}
+		}
+	}
+}
@@ -0,0 +1,375 @@
+package bandit
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/gob"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// Persistable is implemented by Bandit algorithms whose internal state can be
+// checkpointed to, and restored from, a Storage backend.
+type Persistable interface {
+	Bandit
+	encoding.BinaryMarshaler
+	encoding.BinaryUnmarshaler
+}
+
+// Storage is implemented by anything that can durably save and load named
+// byte blobs, such as a bandit's marshalled state.
+type Storage interface {
+	Save(name string, state []byte) error
+	Load(name string) ([]byte, error)
+}
+
+// ucbState is the gob-encoded payload used by UCB's MarshalBinary.
+type ucbState struct {
+	Counts  []int
+	Rewards []float64
+}
+
+// MarshalBinary encodes the UCB's counts and rewards.
+func (b *UCB) MarshalBinary() ([]byte, error) {
+	b.RLock()
+	defer b.RUnlock()
+
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(ucbState{Counts: b.Counts, Rewards: b.Rewards}); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// UnmarshalBinary restores counts and rewards previously produced by
+// MarshalBinary.
+func (b *UCB) UnmarshalBinary(data []byte) error {
+	var s ucbState
+	if err := gob.NewDecoder(bytes.NewReader(data)).Decode(&s); err != nil {
+		return err
+	}
+
+	b.Lock()
+	defer b.Unlock()
+	b.Counts = s.Counts
+	b.Rewards = s.Rewards
+	return nil
+}
+
+// ucb1TunedState is the gob-encoded payload used by UCB1Tuned's MarshalBinary.
+type ucb1TunedState struct {
+	Counts  []int
+	Rewards []float64
+	M2      []float64
+}
+
+// MarshalBinary encodes the UCB1Tuned's counts, rewards and running variance.
+func (b *UCB1Tuned) MarshalBinary() ([]byte, error) {
+	b.RLock()
+	defer b.RUnlock()
+
+	var buf bytes.Buffer
+	state := ucb1TunedState{Counts: b.Counts, Rewards: b.Rewards, M2: b.m2}
+	if err := gob.NewEncoder(&buf).Encode(state); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// UnmarshalBinary restores state previously produced by MarshalBinary.
+func (b *UCB1Tuned) UnmarshalBinary(data []byte) error {
+	var s ucb1TunedState
+	if err := gob.NewDecoder(bytes.NewReader(data)).Decode(&s); err != nil {
+		return err
+	}
+
+	b.Lock()
+	defer b.Unlock()
+	b.Counts = s.Counts
+	b.Rewards = s.Rewards
+	b.m2 = s.M2
+	return nil
+}
+
+// ucbVState is the gob-encoded payload used by UCBV's MarshalBinary.
+type ucbVState struct {
+	Counts  []int
+	Rewards []float64
+	M2      []float64
+	Zeta    float64
+	B       float64
+}
+
+// MarshalBinary encodes the UCBV's counts, rewards, running variance and
+// configuration.
+func (b *UCBV) MarshalBinary() ([]byte, error) {
+	b.RLock()
+	defer b.RUnlock()
+
+	var buf bytes.Buffer
+	state := ucbVState{Counts: b.Counts, Rewards: b.Rewards, M2: b.m2, Zeta: b.Zeta, B: b.B}
+	if err := gob.NewEncoder(&buf).Encode(state); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// UnmarshalBinary restores state previously produced by MarshalBinary.
+func (b *UCBV) UnmarshalBinary(data []byte) error {
+	var s ucbVState
+	if err := gob.NewDecoder(bytes.NewReader(data)).Decode(&s); err != nil {
+		return err
+	}
+
+	b.Lock()
+	defer b.Unlock()
+	b.Counts = s.Counts
+	b.Rewards = s.Rewards
+	b.m2 = s.M2
+	b.Zeta = s.Zeta
+	b.B = s.B
+	return nil
+}
+
+// klucbState is the gob-encoded payload used by KLUCB's MarshalBinary.
+type klucbState struct {
+	Counts  []int
+	Rewards []float64
+	C       float64
+}
+
+// MarshalBinary encodes the KLUCB's counts, rewards and confidence constant.
+func (b *KLUCB) MarshalBinary() ([]byte, error) {
+	b.RLock()
+	defer b.RUnlock()
+
+	var buf bytes.Buffer
+	state := klucbState{Counts: b.Counts, Rewards: b.Rewards, C: b.C}
+	if err := gob.NewEncoder(&buf).Encode(state); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// UnmarshalBinary restores state previously produced by MarshalBinary.
+func (b *KLUCB) UnmarshalBinary(data []byte) error {
+	var s klucbState
+	if err := gob.NewDecoder(bytes.NewReader(data)).Decode(&s); err != nil {
+		return err
+	}
+
+	b.Lock()
+	defer b.Unlock()
+	b.Counts = s.Counts
+	b.Rewards = s.Rewards
+	b.C = s.C
+	return nil
+}
+
+// slidingWindowUCBState is the gob-encoded payload used by
+// SlidingWindowUCB's MarshalBinary.
+type slidingWindowUCBState struct {
+	NArms  int
+	Window []slidingWindowEntry
+	W      int
+	Pos    int
+	Filled bool
+}
+
+// MarshalBinary encodes the SlidingWindowUCB's ring buffer and position.
+func (b *SlidingWindowUCB) MarshalBinary() ([]byte, error) {
+	b.RLock()
+	defer b.RUnlock()
+
+	var buf bytes.Buffer
+	state := slidingWindowUCBState{
+		NArms:  b.nArms,
+		Window: b.window,
+		W:      b.w,
+		Pos:    b.pos,
+		Filled: b.filled,
+	}
+	if err := gob.NewEncoder(&buf).Encode(state); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// UnmarshalBinary restores state previously produced by MarshalBinary.
+func (b *SlidingWindowUCB) UnmarshalBinary(data []byte) error {
+	var s slidingWindowUCBState
+	if err := gob.NewDecoder(bytes.NewReader(data)).Decode(&s); err != nil {
+		return err
+	}
+
+	b.Lock()
+	defer b.Unlock()
+	b.nArms = s.NArms
+	b.window = s.Window
+	b.w = s.W
+	b.pos = s.Pos
+	b.filled = s.Filled
+	return nil
+}
+
+// discountedUCBState is the gob-encoded payload used by DiscountedUCB's
+// MarshalBinary.
+type discountedUCBState struct {
+	N     []float64
+	S     []float64
+	Gamma float64
+	Xi    float64
+}
+
+// MarshalBinary encodes the DiscountedUCB's discounted counts, reward sums
+// and configuration.
+func (b *DiscountedUCB) MarshalBinary() ([]byte, error) {
+	b.RLock()
+	defer b.RUnlock()
+
+	var buf bytes.Buffer
+	state := discountedUCBState{N: b.n, S: b.s, Gamma: b.Gamma, Xi: b.Xi}
+	if err := gob.NewEncoder(&buf).Encode(state); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// UnmarshalBinary restores state previously produced by MarshalBinary.
+func (b *DiscountedUCB) UnmarshalBinary(data []byte) error {
+	var s discountedUCBState
+	if err := gob.NewDecoder(bytes.NewReader(data)).Decode(&s); err != nil {
+		return err
+	}
+
+	b.Lock()
+	defer b.Unlock()
+	b.n = s.N
+	b.s = s.S
+	b.Gamma = s.Gamma
+	b.Xi = s.Xi
+	return nil
+}
+
+// MemoryStorage is an in-memory Storage backend, useful for tests and for
+// composing with another backend as a cache.
+type MemoryStorage struct {
+	mu    sync.RWMutex
+	state map[string][]byte
+}
+
+// NewMemoryStorage returns an empty MemoryStorage.
+func NewMemoryStorage() *MemoryStorage {
+	return &MemoryStorage{state: make(map[string][]byte)}
+}
+
+// Save stores a copy of state under name, overwriting any previous value.
+func (s *MemoryStorage) Save(name string, state []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	cp := make([]byte, len(state))
+	copy(cp, state)
+	s.state[name] = cp
+	return nil
+}
+
+// Load returns a copy of the state last saved under name.
+func (s *MemoryStorage) Load(name string) ([]byte, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	state, ok := s.state[name]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	cp := make([]byte, len(state))
+	copy(cp, state)
+	return cp, nil
+}
+
+// FileStorage is a Storage backend that persists each name as a file inside
+// Dir. Save writes to a temporary file in the same directory, fsyncs it and
+// renames it into place, so a crash mid-write can never leave a corrupt or
+// partial file under name. The temporary file is removed when Save returns;
+// a crash before then leaves it in Dir.
+type FileStorage struct {
+	Dir string
+}
+
+// NewFileStorage returns a FileStorage rooted at dir. dir must already
+// exist.
+func NewFileStorage(dir string) *FileStorage {
+	return &FileStorage{Dir: dir}
+}
+
+// Save durably writes state to name via a write-fsync-rename sequence.
+func (s *FileStorage) Save(name string, state []byte) error {
+	path := filepath.Join(s.Dir, name)
+
+	tmp, err := os.CreateTemp(s.Dir, name+".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(state); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// Load reads the state last saved under name.
+func (s *FileStorage) Load(name string) ([]byte, error) {
+	data, err := os.ReadFile(filepath.Join(s.Dir, name))
+	if os.IsNotExist(err) {
+		return nil, ErrNotFound
+	}
+	return data, err
+}
+
+// PersistEvery starts a background goroutine that saves b's marshalled
+// state under key in s every d. Any error from MarshalBinary or Save is
+// reported to onError, which may be nil to discard them; a long-running
+// service should pass a hook that logs or alerts so a persistently failing
+// backend doesn't fail silently forever. PersistEvery returns a stop
+// function that halts the goroutine; callers should invoke it once they're
+// done with b.
+func PersistEvery(b Persistable, d time.Duration, s Storage, key string, onError func(error)) (stop func()) {
+	done := make(chan struct{})
+
+	go func() {
+		ticker := time.NewTicker(d)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				state, err := b.MarshalBinary()
+				if err != nil {
+					if onError != nil {
+						onError(err)
+					}
+					continue
+				}
+				if err := s.Save(key, state); err != nil && onError != nil {
+					onError(err)
+				}
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	return func() { close(done) }
+}
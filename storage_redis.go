@@ -0,0 +1,33 @@
+package bandit
+
+import (
+	"context"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// RedisStorage is a Storage backend that saves state as keys in Redis. It's
+// a thin wrapper: keys are written with no expiry, and replication and
+// persistence are left to the Redis server behind Client.
+type RedisStorage struct {
+	Client *redis.Client
+}
+
+// NewRedisStorage returns a RedisStorage backed by client.
+func NewRedisStorage(client *redis.Client) *RedisStorage {
+	return &RedisStorage{Client: client}
+}
+
+// Save sets name to state with no expiry.
+func (s *RedisStorage) Save(name string, state []byte) error {
+	return s.Client.Set(context.Background(), name, state, 0).Err()
+}
+
+// Load returns the value currently set for name.
+func (s *RedisStorage) Load(name string) ([]byte, error) {
+	data, err := s.Client.Get(context.Background(), name).Bytes()
+	if err == redis.Nil {
+		return nil, ErrNotFound
+	}
+	return data, err
+}
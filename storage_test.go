@@ -0,0 +1,166 @@
+package bandit
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestMemoryStorageSaveLoad(t *testing.T) {
+	s := NewMemoryStorage()
+
+	if _, err := s.Load("missing"); err != ErrNotFound {
+		t.Fatalf("Load of missing key = %v, want ErrNotFound", err)
+	}
+
+	if err := s.Save("key", []byte("state")); err != nil {
+		t.Fatalf("Save returned an error: %v", err)
+	}
+	got, err := s.Load("key")
+	if err != nil {
+		t.Fatalf("Load returned an error: %v", err)
+	}
+	if string(got) != "state" {
+		t.Fatalf("Load = %q, want %q", got, "state")
+	}
+}
+
+func TestFileStorageSaveLoad(t *testing.T) {
+	dir := t.TempDir()
+	s := NewFileStorage(dir)
+
+	if err := s.Save("key", []byte("state")); err != nil {
+		t.Fatalf("Save returned an error: %v", err)
+	}
+
+	got, err := s.Load("key")
+	if err != nil {
+		t.Fatalf("Load returned an error: %v", err)
+	}
+	if string(got) != "state" {
+		t.Fatalf("Load = %q, want %q", got, "state")
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatalf("ReadDir returned an error: %v", err)
+	}
+	if len(entries) != 1 || entries[0].Name() != "key" {
+		t.Fatalf("directory contains %v, want only the final file (no leftover tmp files)", entries)
+	}
+}
+
+func TestFileStorageLoadMissing(t *testing.T) {
+	s := NewFileStorage(t.TempDir())
+
+	if _, err := s.Load("missing"); err != ErrNotFound {
+		t.Fatalf("Load of missing key = %v, want ErrNotFound", err)
+	}
+}
+
+func TestFileStorageSaveOverwritesAtomically(t *testing.T) {
+	dir := t.TempDir()
+	s := NewFileStorage(dir)
+
+	if err := s.Save("key", []byte("first")); err != nil {
+		t.Fatalf("Save returned an error: %v", err)
+	}
+	if err := s.Save("key", []byte("second")); err != nil {
+		t.Fatalf("Save returned an error: %v", err)
+	}
+
+	got, err := os.ReadFile(filepath.Join(dir, "key"))
+	if err != nil {
+		t.Fatalf("ReadFile returned an error: %v", err)
+	}
+	if string(got) != "second" {
+		t.Fatalf("file contains %q, want %q", got, "second")
+	}
+}
+
+func TestUCBMarshalUnmarshalRoundTrip(t *testing.T) {
+	orig, err := NewUCB([]int{3, 1}, []float64{0.5, 0.75})
+	if err != nil {
+		t.Fatalf("NewUCB returned an error: %v", err)
+	}
+
+	data, err := orig.MarshalBinary()
+	if err != nil {
+		t.Fatalf("MarshalBinary returned an error: %v", err)
+	}
+
+	restored := &UCB{}
+	if err := restored.UnmarshalBinary(data); err != nil {
+		t.Fatalf("UnmarshalBinary returned an error: %v", err)
+	}
+
+	if got := restored.GetCounts(); got[0] != 3 || got[1] != 1 {
+		t.Fatalf("restored counts = %v, want [3 1]", got)
+	}
+	if got := restored.GetRewards(); got[0] != 0.5 || got[1] != 0.75 {
+		t.Fatalf("restored rewards = %v, want [0.5 0.75]", got)
+	}
+}
+
+func TestPersistEverySavesState(t *testing.T) {
+	u, err := NewUCB([]int{1}, []float64{0.5})
+	if err != nil {
+		t.Fatalf("NewUCB returned an error: %v", err)
+	}
+
+	s := NewMemoryStorage()
+	stop := PersistEvery(u, time.Millisecond, s, "key", nil)
+	defer stop()
+
+	waitForSave(t, s, "key")
+}
+
+// failingStorage is a Storage whose Save always fails, used to exercise
+// PersistEvery's error reporting.
+type failingStorage struct{}
+
+func (failingStorage) Save(name string, state []byte) error { return errFailingStorage }
+func (failingStorage) Load(name string) ([]byte, error)     { return nil, errFailingStorage }
+
+var errFailingStorage = errors.New("storage_test: save always fails")
+
+func TestPersistEveryReportsSaveErrors(t *testing.T) {
+	u, err := NewUCB([]int{1}, []float64{0.5})
+	if err != nil {
+		t.Fatalf("NewUCB returned an error: %v", err)
+	}
+
+	errs := make(chan error, 1)
+	stop := PersistEvery(u, time.Millisecond, failingStorage{}, "key", func(err error) {
+		select {
+		case errs <- err:
+		default:
+		}
+	})
+	defer stop()
+
+	select {
+	case err := <-errs:
+		if err != errFailingStorage {
+			t.Fatalf("onError got %v, want %v", err, errFailingStorage)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("PersistEvery never reported the Save error")
+	}
+}
+
+// waitForSave polls s for a value under key, failing the test if it never
+// appears.
+func waitForSave(t *testing.T, s *MemoryStorage, key string) {
+	t.Helper()
+	deadline := time.Now().Add(time.Second)
+	for time.Now().Before(deadline) {
+		if _, err := s.Load(key); err == nil {
+			return
+		}
+		time.Sleep(time.Millisecond)
+	}
+	t.Fatalf("PersistEvery never saved state under %q", key)
+}
@@ -0,0 +1,281 @@
+package bandit
+
+import (
+	"math"
+	"sync"
+)
+
+// defaultSlidingWindow, defaultDiscountedGamma and defaultDiscountedXi are
+// the configuration values used when constructing "sliding-window-ucb" or
+// "discounted-ucb" via the registry, where no per-instance configuration is
+// possible.
+const (
+	defaultSlidingWindow   = 200
+	defaultDiscountedGamma = 0.99
+	defaultDiscountedXi    = 0.5
+)
+
+func init() {
+	Register("sliding-window-ucb", func() Bandit { return &SlidingWindowUCB{w: defaultSlidingWindow} })
+	Register("discounted-ucb", func() Bandit { return &DiscountedUCB{Gamma: defaultDiscountedGamma, Xi: defaultDiscountedXi} })
+}
+
+// slidingWindowEntry records a single (arm, reward) observation kept in a
+// SlidingWindowUCB's ring buffer. Fields are exported so the entry round-trips
+// through gob in MarshalBinary/UnmarshalBinary.
+type slidingWindowEntry struct {
+	Arm    int
+	Reward float64
+}
+
+// SlidingWindowUCB adapts UCB1 to non-stationary rewards by only ever
+// considering the last W plays: counts and means are recomputed from that
+// window on every SelectArm, so arms that stop paying off are forgotten
+// once their good observations fall out of the window.
+type SlidingWindowUCB struct {
+	sync.RWMutex
+	nArms  int
+	window []slidingWindowEntry
+	w      int
+	pos    int
+	filled bool
+}
+
+// NewSlidingWindowUCB returns a pointer to a SlidingWindowUCB for nArms arms
+// that only considers the last w plays.
+func NewSlidingWindowUCB(nArms, w int) (*SlidingWindowUCB, error) {
+	b := &SlidingWindowUCB{w: w}
+	if err := b.Init(nArms); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+// Init resets the sliding window for nArms arms, keeping the window size
+// set at construction.
+func (b *SlidingWindowUCB) Init(nArms int) error {
+	b.Lock()
+	defer b.Unlock()
+
+	if nArms < 1 {
+		return ErrInvalidArms
+	}
+	if b.w < 1 {
+		return ErrInvalidWindow
+	}
+	b.nArms = nArms
+	b.window = make([]slidingWindowEntry, b.w)
+	b.pos = 0
+	b.filled = false
+	return nil
+}
+
+// windowStats recomputes per-arm counts and means from the current window.
+// Callers must hold at least a read lock.
+func (b *SlidingWindowUCB) windowStats() ([]int, []float64) {
+	counts := make([]int, b.nArms)
+	sums := make([]float64, b.nArms)
+
+	n := b.pos
+	if b.filled {
+		n = b.w
+	}
+	for i := 0; i < n; i++ {
+		e := b.window[i]
+		counts[e.Arm]++
+		sums[e.Arm] += e.Reward
+	}
+
+	means := make([]float64, b.nArms)
+	for i := 0; i < b.nArms; i++ {
+		if counts[i] > 0 {
+			means[i] = sums[i] / float64(counts[i])
+		}
+	}
+	return counts, means
+}
+
+// SelectArm picks the arm with the highest UCB1 value computed over the
+// current window, preferring any arm unplayed within that window.
+func (b *SlidingWindowUCB) SelectArm(probability float64) int {
+	b.RLock()
+	defer b.RUnlock()
+
+	counts, means := b.windowStats()
+	for i := 0; i < b.nArms; i++ {
+		if counts[i] == 0 {
+			return i
+		}
+	}
+
+	totalCounts := sum(counts...)
+	ucbValues := make([]float64, b.nArms)
+	for i := 0; i < b.nArms; i++ {
+		bonus := math.Sqrt((2.0 * math.Log(float64(totalCounts))) / float64(counts[i]))
+		ucbValues[i] = bonus + means[i]
+	}
+
+	return max(ucbValues...)
+}
+
+// Update records a reward for chosenArm, pushing it into the ring buffer and
+// evicting the oldest observation once the window is full.
+func (b *SlidingWindowUCB) Update(chosenArm int, reward float64) error {
+	b.Lock()
+	defer b.Unlock()
+
+	if chosenArm < 0 || chosenArm >= b.nArms {
+		return ErrArmsIndexOutOfRange
+	}
+	if reward < 0 {
+		return ErrInvalidReward
+	}
+
+	b.window[b.pos] = slidingWindowEntry{Arm: chosenArm, Reward: reward}
+	b.pos++
+	if b.pos == b.w {
+		b.pos = 0
+		b.filled = true
+	}
+
+	return nil
+}
+
+// GetCounts returns the per-arm play counts within the current window.
+func (b *SlidingWindowUCB) GetCounts() []int {
+	b.RLock()
+	defer b.RUnlock()
+
+	counts, _ := b.windowStats()
+	return counts
+}
+
+// GetRewards returns the per-arm mean reward within the current window.
+func (b *SlidingWindowUCB) GetRewards() []float64 {
+	b.RLock()
+	defer b.RUnlock()
+
+	_, means := b.windowStats()
+	return means
+}
+
+// DiscountedUCB adapts UCB1 to non-stationary rewards by discounting older
+// observations: each Update applies n_a <- gamma*n_a + 1 and
+// S_a <- gamma*S_a + r for a discount gamma in (0, 1], so recent rewards
+// dominate an arm's mean and exploration bonus.
+type DiscountedUCB struct {
+	sync.RWMutex
+	Gamma float64
+	Xi    float64
+	n     []float64 // discounted play counts, n_a
+	s     []float64 // discounted reward sums, S_a
+}
+
+// discountedRewardBound is the assumed upper bound B on a single reward,
+// matching the [0, 1] reward range the rest of the package assumes.
+const discountedRewardBound = 1.0
+
+// NewDiscountedUCB returns a pointer to a DiscountedUCB for nArms arms with
+// discount gamma and exploration constant xi.
+func NewDiscountedUCB(nArms int, gamma, xi float64) (*DiscountedUCB, error) {
+	b := &DiscountedUCB{Gamma: gamma, Xi: xi}
+	if err := b.Init(nArms); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+// Init initialises the discounted counts and reward sums for nArms arms.
+func (b *DiscountedUCB) Init(nArms int) error {
+	b.Lock()
+	defer b.Unlock()
+
+	if nArms < 1 {
+		return ErrInvalidArms
+	}
+	b.n = make([]float64, nArms)
+	b.s = make([]float64, nArms)
+	return nil
+}
+
+// SelectArm plays every arm at least once, then picks the arm with the
+// highest discounted UCB value: mean + 2*B*sqrt(xi*ln(sum n_a) / n_a).
+func (b *DiscountedUCB) SelectArm(probability float64) int {
+	b.RLock()
+	defer b.RUnlock()
+
+	nArms := len(b.n)
+	for i := 0; i < nArms; i++ {
+		if b.n[i] == 0 {
+			return i
+		}
+	}
+
+	total := 0.0
+	for _, n := range b.n {
+		total += n
+	}
+	logTotal := math.Log(total)
+
+	ucbValues := make([]float64, nArms)
+	for i := 0; i < nArms; i++ {
+		mean := b.s[i] / b.n[i]
+		bonus := 2 * discountedRewardBound * math.Sqrt(b.Xi*logTotal/b.n[i])
+		ucbValues[i] = mean + bonus
+	}
+
+	return max(ucbValues...)
+}
+
+// Update discounts every arm's running count and reward sum by Gamma for
+// the elapsed round, then folds the newly observed reward into chosenArm.
+// Discounting every arm, not just chosenArm, is what makes an arm that has
+// stopped being played actually fade: its statistics decay round over
+// round instead of sitting frozen until it happens to be replayed.
+func (b *DiscountedUCB) Update(chosenArm int, reward float64) error {
+	b.Lock()
+	defer b.Unlock()
+
+	if chosenArm < 0 || chosenArm >= len(b.n) {
+		return ErrArmsIndexOutOfRange
+	}
+	if reward < 0 {
+		return ErrInvalidReward
+	}
+
+	for i := range b.n {
+		b.n[i] *= b.Gamma
+		b.s[i] *= b.Gamma
+	}
+	b.n[chosenArm]++
+	b.s[chosenArm] += reward
+
+	return nil
+}
+
+// GetCounts returns the discounted play counts, truncated to integers to
+// satisfy the Bandit interface.
+func (b *DiscountedUCB) GetCounts() []int {
+	b.RLock()
+	defer b.RUnlock()
+
+	counts := make([]int, len(b.n))
+	for i, n := range b.n {
+		counts[i] = int(n)
+	}
+	return counts
+}
+
+// GetRewards returns the discounted mean reward per arm.
+func (b *DiscountedUCB) GetRewards() []float64 {
+	b.RLock()
+	defer b.RUnlock()
+
+	rewards := make([]float64, len(b.s))
+	for i := range rewards {
+		if b.n[i] > 0 {
+			rewards[i] = b.s[i] / b.n[i]
+		}
+	}
+	return rewards
+}
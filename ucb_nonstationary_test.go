@@ -0,0 +1,63 @@
+package bandit
+
+import "testing"
+
+func TestSlidingWindowUCBEvictsOldPlays(t *testing.T) {
+	b, err := NewSlidingWindowUCB(2, 3)
+	if err != nil {
+		t.Fatalf("NewSlidingWindowUCB returned an error: %v", err)
+	}
+
+	// Fill the window entirely with plays of arm 0, then play arm 1 once.
+	for i := 0; i < 3; i++ {
+		if err := b.Update(0, 1); err != nil {
+			t.Fatalf("Update returned an error: %v", err)
+		}
+	}
+	if err := b.Update(1, 0); err != nil {
+		t.Fatalf("Update returned an error: %v", err)
+	}
+
+	counts := b.GetCounts()
+	if counts[0] != 2 {
+		t.Fatalf("counts[0] = %d, want 2 (one play should have been evicted)", counts[0])
+	}
+	if counts[1] != 1 {
+		t.Fatalf("counts[1] = %d, want 1", counts[1])
+	}
+}
+
+func TestSlidingWindowUCBRejectsZeroWindow(t *testing.T) {
+	if _, err := NewSlidingWindowUCB(2, 0); err != ErrInvalidWindow {
+		t.Fatalf("NewSlidingWindowUCB(2, 0) = %v, want ErrInvalidWindow", err)
+	}
+}
+
+func TestDiscountedUCBDecaysUnplayedArms(t *testing.T) {
+	b, err := NewDiscountedUCB(2, 0.5, 0.5)
+	if err != nil {
+		t.Fatalf("NewDiscountedUCB returned an error: %v", err)
+	}
+
+	// Play both arms once so neither count is zero.
+	if err := b.Update(0, 1); err != nil {
+		t.Fatalf("Update returned an error: %v", err)
+	}
+	if err := b.Update(1, 1); err != nil {
+		t.Fatalf("Update returned an error: %v", err)
+	}
+
+	before := b.GetCounts()[1]
+
+	// Keep playing arm 0 only; arm 1's discounted count must keep shrinking
+	// even though it is never played again.
+	for i := 0; i < 5; i++ {
+		if err := b.Update(0, 1); err != nil {
+			t.Fatalf("Update returned an error: %v", err)
+		}
+	}
+
+	if n := b.n[1]; n >= float64(before) {
+		t.Fatalf("arm 1's discounted count did not decay: before=%d, after=%v", before, n)
+	}
+}
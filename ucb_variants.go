@@ -0,0 +1,374 @@
+package bandit
+
+import (
+	"math"
+	"sync"
+)
+
+// defaultUCBVZeta and defaultUCBVBound are the UCB-V exploration constant
+// and reward bound used when constructing a "ucb-v" via the registry, where
+// no per-instance configuration is possible.
+const (
+	defaultUCBVZeta  = 1.2
+	defaultUCBVBound = 1.0
+	// defaultKLUCBConstant is the KL-UCB confidence constant used when
+	// constructing a "kl-ucb" via the registry.
+	defaultKLUCBConstant = 3.0
+)
+
+func init() {
+	Register("ucb1-tuned", func() Bandit { return &UCB1Tuned{} })
+	Register("ucb-v", func() Bandit { return &UCBV{Zeta: defaultUCBVZeta, B: defaultUCBVBound} })
+	Register("kl-ucb", func() Bandit { return &KLUCB{C: defaultKLUCBConstant} })
+}
+
+// UCB1Tuned is a variant of UCB1 that tightens the exploration bonus using
+// an estimate of each arm's reward variance, computed online via Welford's
+// algorithm.
+type UCB1Tuned struct {
+	sync.RWMutex
+	Counts  []int
+	Rewards []float64
+	m2      []float64 // Welford's running sum of squared differences from the mean, per arm
+}
+
+// Init initialises the counts, rewards and variance accumulators for nArms
+// arms.
+func (b *UCB1Tuned) Init(nArms int) error {
+	b.Lock()
+	defer b.Unlock()
+
+	if nArms < 1 {
+		return ErrInvalidArms
+	}
+	b.Counts = make([]int, nArms)
+	b.Rewards = make([]float64, nArms)
+	b.m2 = make([]float64, nArms)
+	return nil
+}
+
+// SelectArm plays every arm once, then picks the arm with the highest
+// UCB1-Tuned value: mean + sqrt((ln n / n_a) * min(1/4, variance_a + sqrt(2 ln n / n_a))).
+func (b *UCB1Tuned) SelectArm(probability float64) int {
+	b.RLock()
+	defer b.RUnlock()
+
+	nArms := len(b.Counts)
+	for i := 0; i < nArms; i++ {
+		if b.Counts[i] == 0 {
+			return i
+		}
+	}
+
+	totalCounts := sum(b.Counts...)
+	logTotal := math.Log(float64(totalCounts))
+	ucbValues := make([]float64, nArms)
+
+	for i := 0; i < nArms; i++ {
+		n := float64(b.Counts[i])
+		variance := b.m2[i] / n
+		vBound := variance + math.Sqrt(2*logTotal/n)
+		if vBound > 0.25 {
+			vBound = 0.25
+		}
+		bonus := math.Sqrt((logTotal / n) * vBound)
+		ucbValues[i] = bonus + b.Rewards[i]
+	}
+
+	return max(ucbValues...)
+}
+
+// Update records reward for chosenArm, updating its running mean and
+// variance via Welford's online algorithm.
+func (b *UCB1Tuned) Update(chosenArm int, reward float64) error {
+	b.Lock()
+	defer b.Unlock()
+
+	if chosenArm < 0 || chosenArm >= len(b.Rewards) {
+		return ErrArmsIndexOutOfRange
+	}
+	if reward < 0 {
+		return ErrInvalidReward
+	}
+
+	b.Counts[chosenArm]++
+	n := float64(b.Counts[chosenArm])
+
+	mean := b.Rewards[chosenArm]
+	delta := reward - mean
+	mean += delta / n
+	b.Rewards[chosenArm] = mean
+	b.m2[chosenArm] += delta * (reward - mean)
+
+	return nil
+}
+
+// GetCounts returns the counts.
+func (b *UCB1Tuned) GetCounts() []int {
+	b.RLock()
+	defer b.RUnlock()
+
+	sCopy := make([]int, len(b.Counts))
+	copy(sCopy, b.Counts)
+	return sCopy
+}
+
+// GetRewards returns the rewards.
+func (b *UCB1Tuned) GetRewards() []float64 {
+	b.RLock()
+	defer b.RUnlock()
+
+	sCopy := make([]float64, len(b.Rewards))
+	copy(sCopy, b.Rewards)
+	return sCopy
+}
+
+// NewUCB1Tuned returns a pointer to an initialised UCB1Tuned for nArms arms.
+func NewUCB1Tuned(nArms int) (*UCB1Tuned, error) {
+	b := &UCB1Tuned{}
+	if err := b.Init(nArms); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+// UCBV is the UCB-V algorithm, which like UCB1Tuned bounds its exploration
+// bonus using the per-arm reward variance, but adds a second bonus term,
+// 3*Zeta*B*ln n / n_a, that shrinks as 1/n_a; both bonus terms scale with the
+// exploration constant Zeta.
+type UCBV struct {
+	sync.RWMutex
+	Counts  []int
+	Rewards []float64
+	Zeta    float64 // exploration constant
+	B       float64 // upper bound on the reward
+	m2      []float64
+}
+
+// NewUCBV returns a pointer to an initialised UCBV for nArms arms, with
+// exploration constant zeta and reward bound b.
+func NewUCBV(nArms int, zeta, b float64) (*UCBV, error) {
+	u := &UCBV{Zeta: zeta, B: b}
+	if err := u.Init(nArms); err != nil {
+		return nil, err
+	}
+	return u, nil
+}
+
+// Init initialises the counts, rewards and variance accumulators for nArms
+// arms.
+func (b *UCBV) Init(nArms int) error {
+	b.Lock()
+	defer b.Unlock()
+
+	if nArms < 1 {
+		return ErrInvalidArms
+	}
+	b.Counts = make([]int, nArms)
+	b.Rewards = make([]float64, nArms)
+	b.m2 = make([]float64, nArms)
+	return nil
+}
+
+// SelectArm plays every arm once, then picks the arm with the highest
+// UCB-V value: mean + sqrt(2*zeta*variance*ln n / n_a) + 3*zeta*B*ln n / n_a.
+func (b *UCBV) SelectArm(probability float64) int {
+	b.RLock()
+	defer b.RUnlock()
+
+	nArms := len(b.Counts)
+	for i := 0; i < nArms; i++ {
+		if b.Counts[i] == 0 {
+			return i
+		}
+	}
+
+	totalCounts := sum(b.Counts...)
+	logTotal := math.Log(float64(totalCounts))
+	ucbValues := make([]float64, nArms)
+
+	for i := 0; i < nArms; i++ {
+		n := float64(b.Counts[i])
+		variance := b.m2[i] / n
+		bonus := math.Sqrt(2*b.Zeta*variance*logTotal/n) + 3*b.Zeta*b.B*logTotal/n
+		ucbValues[i] = bonus + b.Rewards[i]
+	}
+
+	return max(ucbValues...)
+}
+
+// Update records reward for chosenArm, updating its running mean and
+// variance via Welford's online algorithm.
+func (b *UCBV) Update(chosenArm int, reward float64) error {
+	b.Lock()
+	defer b.Unlock()
+
+	if chosenArm < 0 || chosenArm >= len(b.Rewards) {
+		return ErrArmsIndexOutOfRange
+	}
+	if reward < 0 {
+		return ErrInvalidReward
+	}
+
+	b.Counts[chosenArm]++
+	n := float64(b.Counts[chosenArm])
+
+	mean := b.Rewards[chosenArm]
+	delta := reward - mean
+	mean += delta / n
+	b.Rewards[chosenArm] = mean
+	b.m2[chosenArm] += delta * (reward - mean)
+
+	return nil
+}
+
+// GetCounts returns the counts.
+func (b *UCBV) GetCounts() []int {
+	b.RLock()
+	defer b.RUnlock()
+
+	sCopy := make([]int, len(b.Counts))
+	copy(sCopy, b.Counts)
+	return sCopy
+}
+
+// GetRewards returns the rewards.
+func (b *UCBV) GetRewards() []float64 {
+	b.RLock()
+	defer b.RUnlock()
+
+	sCopy := make([]float64, len(b.Rewards))
+	copy(sCopy, b.Rewards)
+	return sCopy
+}
+
+// klBisectSteps bounds the number of bisection iterations klucbBernoulli
+// runs to converge on q.
+const klBisectSteps = 25
+
+// klBernoulli returns the Kullback-Leibler divergence between two Bernoulli
+// distributions with means p and q.
+func klBernoulli(p, q float64) float64 {
+	const eps = 1e-15
+	p = math.Min(math.Max(p, eps), 1-eps)
+	q = math.Min(math.Max(q, eps), 1-eps)
+	return p*math.Log(p/q) + (1-p)*math.Log((1-p)/(1-q))
+}
+
+// klucbBernoulli finds, via bisection, the largest q in [mean, 1] such that
+// n*KL(mean, q) <= bound, and returns that q.
+func klucbBernoulli(mean float64, n, bound float64) float64 {
+	lo, hi := mean, 1.0
+	for i := 0; i < klBisectSteps; i++ {
+		mid := (lo + hi) / 2
+		if n*klBernoulli(mean, mid) > bound {
+			hi = mid
+		} else {
+			lo = mid
+		}
+	}
+	return lo
+}
+
+// KLUCB is the KL-UCB algorithm for Bernoulli rewards. Instead of a closed
+// form bonus, it picks, per arm, the largest q reachable within the
+// Kullback-Leibler confidence bound n_a*KL(mean_a, q) <= ln n + c*ln ln n,
+// found by bisection.
+type KLUCB struct {
+	sync.RWMutex
+	Counts  []int
+	Rewards []float64
+	C       float64 // KL confidence constant
+}
+
+// NewKLUCB returns a pointer to an initialised KLUCB for nArms arms, with
+// confidence constant c.
+func NewKLUCB(nArms int, c float64) (*KLUCB, error) {
+	b := &KLUCB{C: c}
+	if err := b.Init(nArms); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+// Init initialises the counts and rewards for nArms arms.
+func (b *KLUCB) Init(nArms int) error {
+	b.Lock()
+	defer b.Unlock()
+
+	if nArms < 1 {
+		return ErrInvalidArms
+	}
+	b.Counts = make([]int, nArms)
+	b.Rewards = make([]float64, nArms)
+	return nil
+}
+
+// SelectArm plays every arm once, then picks the arm with the highest
+// KL-UCB index.
+func (b *KLUCB) SelectArm(probability float64) int {
+	b.RLock()
+	defer b.RUnlock()
+
+	nArms := len(b.Counts)
+	for i := 0; i < nArms; i++ {
+		if b.Counts[i] == 0 {
+			return i
+		}
+	}
+
+	totalCounts := sum(b.Counts...)
+	bound := math.Log(float64(totalCounts))
+	if bound > 0 {
+		bound += b.C * math.Log(bound)
+	}
+
+	ucbValues := make([]float64, nArms)
+	for i := 0; i < nArms; i++ {
+		ucbValues[i] = klucbBernoulli(b.Rewards[i], float64(b.Counts[i]), bound)
+	}
+
+	return max(ucbValues...)
+}
+
+// Update records reward, which must be in [0, 1] since KL-UCB models
+// rewards as Bernoulli, for chosenArm.
+func (b *KLUCB) Update(chosenArm int, reward float64) error {
+	b.Lock()
+	defer b.Unlock()
+
+	if chosenArm < 0 || chosenArm >= len(b.Rewards) {
+		return ErrArmsIndexOutOfRange
+	}
+	if reward < 0 || reward > 1 {
+		return ErrInvalidReward
+	}
+
+	b.Counts[chosenArm]++
+	n := float64(b.Counts[chosenArm])
+
+	oldRewards := b.Rewards[chosenArm]
+	b.Rewards[chosenArm] = (oldRewards*(n-1) + reward) / n
+
+	return nil
+}
+
+// GetCounts returns the counts.
+func (b *KLUCB) GetCounts() []int {
+	b.RLock()
+	defer b.RUnlock()
+
+	sCopy := make([]int, len(b.Counts))
+	copy(sCopy, b.Counts)
+	return sCopy
+}
+
+// GetRewards returns the rewards.
+func (b *KLUCB) GetRewards() []float64 {
+	b.RLock()
+	defer b.RUnlock()
+
+	sCopy := make([]float64, len(b.Rewards))
+	copy(sCopy, b.Rewards)
+	return sCopy
+}
@@ -0,0 +1,73 @@
+package bandit
+
+import "testing"
+
+func TestKLUCBBernoulliBound(t *testing.T) {
+	// q must stay within [mean, 1] and grow with a looser bound.
+	tight := klucbBernoulli(0.3, 100, 1.0)
+	loose := klucbBernoulli(0.3, 100, 5.0)
+
+	if tight < 0.3 || tight > 1 {
+		t.Fatalf("klucbBernoulli(0.3, 100, 1.0) = %v, want value in [0.3, 1]", tight)
+	}
+	if loose <= tight {
+		t.Fatalf("klucbBernoulli with a looser bound (%v) should exceed the tighter one (%v)", loose, tight)
+	}
+}
+
+func TestKLUCBUpdateRejectsOutOfRangeReward(t *testing.T) {
+	b, err := NewKLUCB(2, 3)
+	if err != nil {
+		t.Fatalf("NewKLUCB returned an error: %v", err)
+	}
+
+	if err := b.Update(0, 1.5); err != ErrInvalidReward {
+		t.Fatalf("Update(0, 1.5) = %v, want ErrInvalidReward", err)
+	}
+	if err := b.Update(0, -0.1); err != ErrInvalidReward {
+		t.Fatalf("Update(0, -0.1) = %v, want ErrInvalidReward", err)
+	}
+	if err := b.Update(0, 1); err != nil {
+		t.Fatalf("Update(0, 1) returned an error: %v", err)
+	}
+}
+
+func TestUCB1TunedTracksVariance(t *testing.T) {
+	b, err := NewUCB1Tuned(2)
+	if err != nil {
+		t.Fatalf("NewUCB1Tuned returned an error: %v", err)
+	}
+
+	for _, r := range []float64{1, 0, 1, 0} {
+		if err := b.Update(0, r); err != nil {
+			t.Fatalf("Update returned an error: %v", err)
+		}
+	}
+
+	if got := b.GetRewards()[0]; got != 0.5 {
+		t.Fatalf("mean reward = %v, want 0.5", got)
+	}
+	if got := b.m2[0] / 4; !approxEqual(got, 0.25, 1e-9) {
+		t.Fatalf("variance = %v, want 0.25", got)
+	}
+}
+
+func TestUCBVPrefersHigherMean(t *testing.T) {
+	b, err := NewUCBV(2, 1.2, 1)
+	if err != nil {
+		t.Fatalf("NewUCBV returned an error: %v", err)
+	}
+
+	for i := 0; i < 20; i++ {
+		if err := b.Update(0, 1); err != nil {
+			t.Fatalf("Update returned an error: %v", err)
+		}
+		if err := b.Update(1, 0); err != nil {
+			t.Fatalf("Update returned an error: %v", err)
+		}
+	}
+
+	if got := b.SelectArm(0); got != 0 {
+		t.Fatalf("SelectArm = %d, want 0 (the consistently rewarding arm)", got)
+	}
+}
@@ -0,0 +1,24 @@
+package bandit
+
+// sum returns the total of a list of integers.
+func sum(values ...int) int {
+	total := 0
+	for _, v := range values {
+		total += v
+	}
+	return total
+}
+
+// max returns the index of the largest value in values, breaking ties in
+// favour of the earliest index.
+func max(values ...float64) int {
+	maxIndex := 0
+	maxValue := values[0]
+	for i, v := range values {
+		if v > maxValue {
+			maxValue = v
+			maxIndex = i
+		}
+	}
+	return maxIndex
+}